@@ -0,0 +1,448 @@
+// Package color provides a colour value type that can move between the
+// representations a Yeelight bulb understands (RGB, HSV, CIE 1931 xy and
+// colour temperature), plus string parsing and a small named-preset registry.
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Space identifies the representation a Color was built from.
+type Space int
+
+// Supported colour spaces.
+const (
+	SpaceRGB Space = iota
+	SpaceHSV
+	SpaceXY
+	SpaceCT
+)
+
+// RGB is a red/green/blue triplet, each component in the [0,255] range.
+type RGB struct {
+	R, G, B uint8
+}
+
+// HSV is a hue ([0,359]), saturation and value ([0,100]) triplet.
+type HSV struct {
+	H, S, V int
+}
+
+// XY is a CIE 1931 chromaticity coordinate.
+type XY struct {
+	X, Y float64
+}
+
+// Color is a colour value that remembers the space it was created in and can
+// be converted, lossily, to any of the others.
+type Color struct {
+	space Space
+	rgb   RGB
+	hsv   HSV
+	xy    XY
+	ct    int
+}
+
+// Space returns the colour space Color was originally created from.
+func (c Color) Space() Space {
+	return c.space
+}
+
+// NewRGB builds a Color from red, green and blue components.
+func NewRGB(r, g, b uint8) Color {
+	return Color{space: SpaceRGB, rgb: RGB{R: r, G: g, B: b}}
+}
+
+// NewHSV builds a Color from hue ([0,359]), saturation and value ([0,100]).
+func NewHSV(h, s, v int) Color {
+	return Color{space: SpaceHSV, hsv: HSV{H: clamp(h, 0, 359), S: clamp(s, 0, 100), V: clamp(v, 0, 100)}}
+}
+
+// NewXY builds a Color from a CIE 1931 chromaticity coordinate.
+func NewXY(x, y float64) Color {
+	return Color{space: SpaceXY, xy: XY{X: x, Y: y}}
+}
+
+// NewCT builds a Color from a colour temperature, clamped to the
+// 1700-6500K range Yeelight bulbs support.
+func NewCT(kelvin int) Color {
+	return Color{space: SpaceCT, ct: clampCT(kelvin)}
+}
+
+// AsRGB converts Color to its RGB representation, lossily if it was not
+// created as RGB.
+func (c Color) AsRGB() RGB {
+	switch c.space {
+	case SpaceRGB:
+		return c.rgb
+	case SpaceHSV:
+		return hsvToRGB(c.hsv)
+	case SpaceXY:
+		return xyToRGB(c.xy)
+	case SpaceCT:
+		return ctToRGB(c.ct)
+	default:
+		return RGB{}
+	}
+}
+
+// AsHSV converts Color to its HSV representation, lossily if it was not
+// created as HSV.
+func (c Color) AsHSV() HSV {
+	if c.space == SpaceHSV {
+		return c.hsv
+	}
+	return rgbToHSV(c.AsRGB())
+}
+
+// AsXY converts Color to its CIE 1931 xy representation, lossily if it was
+// not created as XY.
+func (c Color) AsXY() XY {
+	if c.space == SpaceXY {
+		return c.xy
+	}
+	return rgbToXY(c.AsRGB())
+}
+
+// AsCT converts Color to an approximate colour temperature in Kelvin,
+// clamped to the 1700-6500K range Yeelight bulbs support.
+func (c Color) AsCT() int {
+	if c.space == SpaceCT {
+		return c.ct
+	}
+	return clampCT(xyToCT(c.AsXY()))
+}
+
+// Parse parses a colour expressed as one of:
+//
+//	rgb:255,128,0
+//	hex:#ff8000
+//	hsv:30,100,100
+//	xy:0.55,0.40
+//	ct:4000
+//
+// or a registered preset name (see Preset).
+func Parse(s string) (Color, error) {
+	prefix, rest, ok := strings.Cut(s, ":")
+	if !ok {
+		if c, ok := Preset(ColorPreset(s)); ok {
+			return c, nil
+		}
+		return Color{}, fmt.Errorf("color: invalid color %q", s)
+	}
+
+	switch prefix {
+	case "rgb":
+		vals, err := parseInts(rest, 3)
+		if err != nil {
+			return Color{}, fmt.Errorf("color: invalid rgb value %q: %w", s, err)
+		}
+		for _, v := range vals {
+			if v < 0 || v > 255 {
+				return Color{}, fmt.Errorf("color: invalid rgb value %q: %w", s, rangeErr("component", v, 0, 255))
+			}
+		}
+		return NewRGB(uint8(vals[0]), uint8(vals[1]), uint8(vals[2])), nil
+	case "hex":
+		return parseHex(rest)
+	case "hsv":
+		vals, err := parseInts(rest, 3)
+		if err != nil {
+			return Color{}, fmt.Errorf("color: invalid hsv value %q: %w", s, err)
+		}
+		switch {
+		case vals[0] < 0 || vals[0] > 359:
+			return Color{}, fmt.Errorf("color: invalid hsv value %q: %w", s, rangeErr("hue", vals[0], 0, 359))
+		case vals[1] < 0 || vals[1] > 100:
+			return Color{}, fmt.Errorf("color: invalid hsv value %q: %w", s, rangeErr("saturation", vals[1], 0, 100))
+		case vals[2] < 0 || vals[2] > 100:
+			return Color{}, fmt.Errorf("color: invalid hsv value %q: %w", s, rangeErr("value", vals[2], 0, 100))
+		}
+		return NewHSV(vals[0], vals[1], vals[2]), nil
+	case "xy":
+		vals, err := parseFloats(rest, 2)
+		if err != nil {
+			return Color{}, fmt.Errorf("color: invalid xy value %q: %w", s, err)
+		}
+		return NewXY(vals[0], vals[1]), nil
+	case "ct":
+		kelvin, err := strconv.Atoi(rest)
+		if err != nil {
+			return Color{}, fmt.Errorf("color: invalid ct value %q: %w", s, err)
+		}
+		return NewCT(kelvin), nil
+	default:
+		return Color{}, fmt.Errorf("color: unknown color format %q", prefix)
+	}
+}
+
+func parseHex(s string) (Color, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return Color{}, fmt.Errorf("color: invalid hex value %q", s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("color: invalid hex value %q: %w", s, err)
+	}
+
+	return NewRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+func parseInts(s string, n int) ([]int, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("expected %d comma-separated values, got %d", n, len(parts))
+	}
+
+	out := make([]int, n)
+	for i, p := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
+
+func parseFloats(s string, n int) ([]float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != n {
+		return nil, fmt.Errorf("expected %d comma-separated values, got %d", n, len(parts))
+	}
+
+	out := make([]float64, n)
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
+
+// rangeErr reports that name's value v fell outside [min,max].
+func rangeErr(name string, v, min, max int) error {
+	return fmt.Errorf("%s %d out of range [%d,%d]", name, v, min, max)
+}
+
+func clamp(v, min, max int) int {
+	switch {
+	case v < min:
+		return min
+	case v > max:
+		return max
+	default:
+		return v
+	}
+}
+
+func clampCT(kelvin int) int {
+	return clamp(kelvin, 1700, 6500)
+}
+
+// rgbToHSV converts RGB to HSV using the standard min/max/delta algorithm.
+func rgbToHSV(c RGB) HSV {
+	r := float64(c.R) / 255
+	g := float64(c.G) / 255
+	b := float64(c.B) / 255
+
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+
+	var h float64
+	switch {
+	case delta == 0:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/delta, 6)
+	case max == g:
+		h = 60 * ((b-r)/delta + 2)
+	default:
+		h = 60 * ((r-g)/delta + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+
+	var s float64
+	if max != 0 {
+		s = delta / max
+	}
+
+	return HSV{H: int(math.Round(h)), S: int(math.Round(s * 100)), V: int(math.Round(max * 100))}
+}
+
+// hsvToRGB converts HSV to RGB.
+func hsvToRGB(c HSV) RGB {
+	h := float64(c.H)
+	s := float64(c.S) / 100
+	v := float64(c.V) / 100
+
+	chroma := v * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - chroma
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return RGB{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+	}
+}
+
+// rgbToXY converts RGB to a CIE 1931 xy chromaticity coordinate, using the
+// sRGB gamma correction and D65 conversion matrix.
+func rgbToXY(c RGB) XY {
+	gammaCorrect := func(v float64) float64 {
+		if v > 0.04045 {
+			return math.Pow((v+0.055)/1.055, 2.4)
+		}
+		return v / 12.92
+	}
+
+	r := gammaCorrect(float64(c.R) / 255)
+	g := gammaCorrect(float64(c.G) / 255)
+	b := gammaCorrect(float64(c.B) / 255)
+
+	x := r*0.4124 + g*0.3576 + b*0.1805
+	y := r*0.2126 + g*0.7152 + b*0.0722
+	z := r*0.0193 + g*0.1192 + b*0.9505
+
+	sum := x + y + z
+	if sum == 0 {
+		return XY{}
+	}
+
+	return XY{X: x / sum, Y: y / sum}
+}
+
+// xyToRGB converts a CIE 1931 xy chromaticity coordinate back to RGB,
+// assuming full (Y=1) brightness.
+func xyToRGB(c XY) RGB {
+	if c.Y == 0 {
+		return RGB{}
+	}
+
+	yy := 1.0
+	xx := (yy / c.Y) * c.X
+	zz := (yy / c.Y) * (1 - c.X - c.Y)
+
+	r := xx*3.2406 - yy*1.5372 - zz*0.4986
+	g := -xx*0.9689 + yy*1.8758 + zz*0.0415
+	b := xx*0.0557 - yy*0.2040 + zz*1.0570
+
+	invGamma := func(v float64) float64 {
+		if v <= 0.0031308 {
+			v = 12.92 * v
+		} else {
+			v = 1.055*math.Pow(v, 1/2.4) - 0.055
+		}
+		return math.Min(1, math.Max(0, v))
+	}
+
+	return RGB{
+		R: uint8(math.Round(invGamma(r) * 255)),
+		G: uint8(math.Round(invGamma(g) * 255)),
+		B: uint8(math.Round(invGamma(b) * 255)),
+	}
+}
+
+// ctToRGB approximates an RGB colour for a colour temperature, using
+// Tanner Helland's widely-used algorithm.
+func ctToRGB(kelvin int) RGB {
+	temp := float64(kelvin) / 100
+
+	var r, g, b float64
+	if temp <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(temp) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(temp-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(temp-60, -0.0755148492)
+	}
+
+	switch {
+	case temp >= 66:
+		b = 255
+	case temp <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(temp-10) - 305.0447927307
+	}
+
+	clamp8 := func(v float64) uint8 {
+		return uint8(math.Round(math.Min(255, math.Max(0, v))))
+	}
+
+	return RGB{R: clamp8(r), G: clamp8(g), B: clamp8(b)}
+}
+
+// xyToCT approximates a correlated colour temperature from a CIE 1931 xy
+// chromaticity coordinate using McCamy's cubic approximation.
+func xyToCT(c XY) int {
+	n := (c.X - 0.3320) / (0.1858 - c.Y)
+	cct := 449*n*n*n + 3525*n*n + 6823.3*n + 5520.33
+	return int(math.Round(cct))
+}
+
+// ColorPreset names a registered, ready-to-use Color.
+type ColorPreset string
+
+// Built-in colour presets.
+const (
+	PresetWarm     ColorPreset = "warm"
+	PresetDaylight ColorPreset = "daylight"
+	PresetCandle   ColorPreset = "candle"
+)
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[ColorPreset]Color{
+		PresetWarm:     NewCT(2700),
+		PresetDaylight: NewCT(5600),
+		PresetCandle:   NewCT(1900),
+	}
+)
+
+// Preset looks up a registered ColorPreset by name.
+func Preset(name ColorPreset) (Color, bool) {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+
+	c, ok := presets[name]
+	return c, ok
+}
+
+// RegisterPreset registers or overrides a named ColorPreset.
+func RegisterPreset(name ColorPreset, c Color) {
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+
+	presets[name] = c
+}
@@ -0,0 +1,247 @@
+package yeelight
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// discoverPollInterval bounds how long DiscoverAll blocks in a single
+// ReadFromUDP call, so it regularly comes back up to check ctx.
+const discoverPollInterval = 200 * time.Millisecond
+
+// DiscoveredDevice describes a bulb found via SSDP discovery, carrying the
+// Yeelight handle to control it alongside the advertisement headers the
+// bulb reported about itself.
+type DiscoveredDevice struct {
+	Yeelight Yeelight
+
+	ID        string
+	Model     string
+	FWVer     string
+	Support   []string
+	Power     string
+	Bright    int
+	ColorMode int
+	CT        int
+	RGB       int
+	Hue       int
+	Sat       int
+	Name      string
+}
+
+// discoverOpts holds the settings built from a list of DiscoverOption.
+type discoverOpts struct {
+	timeout time.Duration
+}
+
+// DiscoverOption configures DiscoverAll.
+type DiscoverOption func(*discoverOpts)
+
+// WithTimeout sets how long DiscoverAll keeps its socket open collecting
+// replies. Defaults to 3 seconds.
+func WithTimeout(d time.Duration) DiscoverOption {
+	return func(o *discoverOpts) {
+		o.timeout = d
+	}
+}
+
+func newDiscoverOpts(opts ...DiscoverOption) discoverOpts {
+	c := discoverOpts{timeout: 3 * time.Second}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// DiscoverAll discovers every Yeelight replying on the local network within
+// the configured window (see WithTimeout), deduplicated by device ID.
+func DiscoverAll(ctx context.Context, opts ...DiscoverOption) ([]Yeelight, error) {
+	cfg := newDiscoverOpts(opts...)
+
+	ssdp, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	pc, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer pc.Close()
+
+	socket := pc.(*net.UDPConn)
+	if _, err := socket.WriteToUDP([]byte(discoverMSG), ssdp); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(cfg.timeout)
+
+	devices := make(map[string]Yeelight)
+	buf := make([]byte, 1024)
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		now := time.Now()
+		if !now.Before(deadline) {
+			break
+		}
+
+		// Use a short read deadline, capped by the overall deadline, so we
+		// regularly come back up to check ctx even if nothing replies.
+		readDeadline := now.Add(discoverPollInterval)
+		if readDeadline.After(deadline) {
+			readDeadline = deadline
+		}
+		if err := socket.SetReadDeadline(readDeadline); err != nil {
+			return nil, err
+		}
+
+		size, _, err := socket.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				continue // short read deadline reached, loop back to re-check ctx
+			}
+			break
+		}
+
+		d, err := parseDiscoveredDevice(string(buf[:size]))
+		if err != nil {
+			continue
+		}
+
+		devices[d.ID] = d.Yeelight
+	}
+
+	if len(devices) == 0 {
+		return nil, ErrDiscoverNoDeviceFound
+	}
+
+	out := make([]Yeelight, 0, len(devices))
+	for _, y := range devices {
+		out = append(out, y)
+	}
+
+	return out, nil
+}
+
+// Monitor joins the SSDP multicast group and streams both replies to an
+// initial M-SEARCH and the periodic NOTIFY advertisements bulbs send when
+// they change state or come online. The returned channel is closed when ctx
+// is done.
+func Monitor(ctx context.Context) (<-chan DiscoveredDevice, error) {
+	group, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		return nil, fmt.Errorf("could not join ssdp multicast group: %w", err)
+	}
+
+	if _, err := conn.WriteToUDP([]byte(discoverMSG), group); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("could not send discovery message: %w", err)
+	}
+
+	ch := make(chan DiscoveredDevice)
+	go func() {
+		defer close(ch)
+		defer conn.Close()
+
+		buf := make([]byte, 1024)
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+				return
+			}
+
+			size, _, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				continue // read timeout, loop back to re-check ctx
+			}
+
+			d, err := parseDiscoveredDevice(string(buf[:size]))
+			if err != nil {
+				continue
+			}
+
+			select {
+			case ch <- *d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// parseDiscoveredDevice parses a DiscoveredDevice out of an SSDP message,
+// whether it is an M-SEARCH reply ("HTTP/1.1 200 OK") or a NOTIFY
+// advertisement ("NOTIFY * HTTP/1.1") — both share the same header block.
+func parseDiscoveredDevice(msg string) (*DiscoveredDevice, error) {
+	if !strings.HasSuffix(msg, crlf+crlf) {
+		msg += crlf
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(msg)))
+	if _, err := reader.ReadLine(); err != nil {
+		return nil, fmt.Errorf("could not read ssdp status line: %w", err)
+	}
+
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && header == nil {
+		return nil, fmt.Errorf("could not read ssdp headers: %w", err)
+	}
+
+	addr := strings.TrimPrefix(header.Get("Location"), "yeelight://")
+	if addr == "" {
+		return nil, fmt.Errorf("missing location header")
+	}
+
+	y, err := New(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var support []string
+	if s := header.Get("Support"); s != "" {
+		support = strings.Fields(s)
+	}
+
+	bright, _ := strconv.Atoi(header.Get("Bright"))
+	colorMode, _ := strconv.Atoi(header.Get("Color_mode"))
+	ct, _ := strconv.Atoi(header.Get("Ct"))
+	rgb, _ := strconv.Atoi(header.Get("Rgb"))
+	hue, _ := strconv.Atoi(header.Get("Hue"))
+	sat, _ := strconv.Atoi(header.Get("Sat"))
+
+	return &DiscoveredDevice{
+		Yeelight:  y,
+		ID:        header.Get("Id"),
+		Model:     header.Get("Model"),
+		FWVer:     header.Get("Fw_ver"),
+		Support:   support,
+		Power:     header.Get("Power"),
+		Bright:    bright,
+		ColorMode: colorMode,
+		CT:        ct,
+		RGB:       rgb,
+		Hue:       hue,
+		Sat:       sat,
+		Name:      header.Get("Name"),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package yeelight
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlowMode selects what a FlowTransition's Value means.
+type FlowMode int
+
+// Supported flow transition modes.
+const (
+	// FlowModeColor interprets Value as a 0xRRGGBB color.
+	FlowModeColor FlowMode = 1
+	// FlowModeColorTemperature interprets Value as a color temperature in Kelvin.
+	FlowModeColorTemperature FlowMode = 2
+	// FlowModeSleep pauses for Duration and ignores Value and Brightness.
+	FlowModeSleep FlowMode = 7
+)
+
+// FlowEndAction selects what the bulb does once a finite Flow completes.
+type FlowEndAction int
+
+// Supported flow end actions.
+const (
+	FlowEndRecover FlowEndAction = 0 // recover the state from before the flow started
+	FlowEndStay    FlowEndAction = 1 // stay at the state set by the last transition
+	FlowEndOff     FlowEndAction = 2 // turn the bulb off
+)
+
+// FlowTransition is a single step of a Flow.
+type FlowTransition struct {
+	Duration   time.Duration
+	Mode       FlowMode
+	Value      int
+	Brightness int
+}
+
+// Flow describes a start_cf color flow expression.
+type Flow struct {
+	// Count is the number of times the flow loops through its Transitions
+	// before stopping. 0 means loop forever.
+	Count int
+
+	// EndAction selects what happens once a finite Flow completes.
+	EndAction FlowEndAction
+
+	Transitions []FlowTransition
+}
+
+// Encode renders f as the comma-joined "dur,mode,val,bri,dur,mode,val,bri,..."
+// expression the bulb expects for start_cf.
+func (f Flow) Encode() string {
+	parts := make([]string, 0, len(f.Transitions)*4)
+	for _, t := range f.Transitions {
+		parts = append(parts,
+			strconv.FormatInt(t.Duration.Milliseconds(), 10),
+			strconv.Itoa(int(t.Mode)),
+			strconv.Itoa(t.Value),
+			strconv.Itoa(t.Brightness),
+		)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// SceneClass selects which kind of state set_scene applies in a single,
+// flicker-free command.
+type SceneClass string
+
+// Supported scene classes.
+const (
+	SceneColor        SceneClass = "color"
+	SceneHSV          SceneClass = "hsv"
+	SceneCT           SceneClass = "ct"
+	SceneCF           SceneClass = "cf"
+	SceneAutoDelayOff SceneClass = "auto_delay_off"
+)
+
+// StartColorFlow starts a color flow animation described by f.
+func (y *yeelight) StartColorFlow(f Flow) error {
+	_, err := y.send(StartColorFlow, f.Count, int(f.EndAction), f.Encode())
+	return err
+}
+
+// StopColorFlow stops any color flow animation currently running.
+func (y *yeelight) StopColorFlow() error {
+	_, err := y.send(StopColorFlow)
+	return err
+}
+
+// SetScene sets class's state in a single command, without the intermediate
+// state transition other setters cause. vals are the class-specific trailing
+// parameters, e.g. SetScene(SceneColor, 0xff0000, 100) or
+// SetScene(SceneCF, flow.Count, int(flow.EndAction), flow.Encode()).
+func (y *yeelight) SetScene(class SceneClass, vals ...interface{}) error {
+	args := append([]interface{}{string(class)}, vals...)
+	_, err := y.send(SetScene, args...)
+	return err
+}
+
+// Prebuilt Flow examples.
+var (
+	// FlowPoliceStrobe alternates red and blue at full brightness, forever.
+	FlowPoliceStrobe = Flow{
+		Count:     0,
+		EndAction: FlowEndRecover,
+		Transitions: []FlowTransition{
+			{Duration: 50 * time.Millisecond, Mode: FlowModeColor, Value: 0xff0000, Brightness: 100},
+			{Duration: 50 * time.Millisecond, Mode: FlowModeColor, Value: 0x0000ff, Brightness: 100},
+		},
+	}
+
+	// FlowSunrise fades from dim warm white up to bright daylight over five minutes, once.
+	FlowSunrise = Flow{
+		Count:     1,
+		EndAction: FlowEndStay,
+		Transitions: []FlowTransition{
+			{Duration: 2 * time.Minute, Mode: FlowModeColorTemperature, Value: 1700, Brightness: 1},
+			{Duration: 2 * time.Minute, Mode: FlowModeColorTemperature, Value: 3000, Brightness: 40},
+			{Duration: time.Minute, Mode: FlowModeColorTemperature, Value: 5600, Brightness: 100},
+		},
+	}
+
+	// FlowCandleFlicker loops a gentle warm, low-brightness flicker forever.
+	FlowCandleFlicker = Flow{
+		Count:     0,
+		EndAction: FlowEndRecover,
+		Transitions: []FlowTransition{
+			{Duration: 800 * time.Millisecond, Mode: FlowModeColorTemperature, Value: 2000, Brightness: 50},
+			{Duration: 800 * time.Millisecond, Mode: FlowModeColorTemperature, Value: 2000, Brightness: 30},
+			{Duration: 800 * time.Millisecond, Mode: FlowModeColorTemperature, Value: 2000, Brightness: 40},
+		},
+	}
+)
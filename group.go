@@ -0,0 +1,202 @@
+package yeelight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/SkYNewZ/go-yeelight/color"
+)
+
+// groupOpts holds the settings built from a list of GroupOption.
+type groupOpts struct {
+	concurrency int
+}
+
+// GroupOption configures a Group.
+type GroupOption func(*groupOpts)
+
+// WithConcurrency bounds how many members a Group method call fans out to at
+// once. Defaults to the number of members.
+func WithConcurrency(n int) GroupOption {
+	return func(o *groupOpts) {
+		o.concurrency = n
+	}
+}
+
+// Group controls several Yeelight devices together, fanning out each call
+// concurrently across its members.
+type Group struct {
+	members []Yeelight
+	opts    groupOpts
+}
+
+// NewGroup builds a Group controlling members together. Use WithOptions to
+// configure it, e.g. to bound its fan-out concurrency.
+func NewGroup(members ...Yeelight) *Group {
+	return &Group{members: members}
+}
+
+// WithOptions applies opts to g and returns it for chaining.
+func (g *Group) WithOptions(opts ...GroupOption) *Group {
+	for _, opt := range opts {
+		opt(&g.opts)
+	}
+
+	return g
+}
+
+// GroupError collects the per-member errors from a Group method call.
+type GroupError struct {
+	errs []error
+}
+
+func (e *GroupError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("group: %d member(s) failed: %s", len(e.errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap exposes the collected per-member errors to errors.Is/As.
+func (e *GroupError) Unwrap() []error {
+	return e.errs
+}
+
+// fanOut calls fn for every member of g, bounded by g.opts.concurrency, and
+// collects any errors into a GroupError.
+func (g *Group) fanOut(fn func(Yeelight) error) error {
+	concurrency := g.opts.concurrency
+	if concurrency <= 0 || concurrency > len(g.members) {
+		concurrency = len(g.members)
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for _, m := range g.members {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(m Yeelight) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(m); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("[%s]: %w", m, err))
+				mu.Unlock()
+			}
+		}(m)
+	}
+
+	wg.Wait()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &GroupError{errs: errs}
+}
+
+// On turns every member of g on.
+func (g *Group) On(opts ...Option) error {
+	return g.fanOut(func(y Yeelight) error { return y.On(opts...) })
+}
+
+// Off turns every member of g off.
+func (g *Group) Off(opts ...Option) error {
+	return g.fanOut(func(y Yeelight) error { return y.Off(opts...) })
+}
+
+// Toggle toggles every member of g.
+func (g *Group) Toggle() error {
+	return g.fanOut(func(y Yeelight) error { return y.Toggle() })
+}
+
+// SetColor sets every member of g to c.
+func (g *Group) SetColor(c color.Color, opts ...Option) error {
+	return g.fanOut(func(y Yeelight) error { return y.SetColor(c, opts...) })
+}
+
+// SetPreset sets every member of g to a registered color.ColorPreset.
+func (g *Group) SetPreset(name color.ColorPreset, opts ...Option) error {
+	return g.fanOut(func(y Yeelight) error { return y.SetPreset(name, opts...) })
+}
+
+// SetBrightness sets every member of g's brightness.
+func (g *Group) SetBrightness(brightness int, opts ...Option) error {
+	return g.fanOut(func(y Yeelight) error { return y.SetBrightness(brightness, opts...) })
+}
+
+// StartColorFlow starts a color flow animation described by f on every member of g.
+func (g *Group) StartColorFlow(f Flow) error {
+	return g.fanOut(func(y Yeelight) error { return y.StartColorFlow(f) })
+}
+
+// StopColorFlow stops any color flow animation currently running on every member of g.
+func (g *Group) StopColorFlow() error {
+	return g.fanOut(func(y Yeelight) error { return y.StopColorFlow() })
+}
+
+// GroupNotification tags a Notification with the address of the member that sent it.
+type GroupNotification struct {
+	Source string
+	*Notification
+}
+
+// Listen aggregates Listen notifications from every member of g into a
+// single channel, tagged with each notification's source address. The
+// channel is closed once ctx is done and every member's stream has drained.
+func (g *Group) Listen(ctx context.Context) (<-chan GroupNotification, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	out := make(chan GroupNotification)
+
+	var wg sync.WaitGroup
+	for _, m := range g.members {
+		ch, err := m.Listen(ctx)
+		if err != nil {
+			// Cancel ctx so the forwarding goroutines already started
+			// for earlier members return; this call does not wait for
+			// them.
+			cancel()
+			go wg.Wait()
+			return nil, fmt.Errorf("[%s]: %w", m, err)
+		}
+
+		wg.Add(1)
+		go func(m Yeelight, ch <-chan *Notification) {
+			defer wg.Done()
+
+			for {
+				select {
+				case n, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case out <- GroupNotification{Source: m.String(), Notification: n}:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(m, ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+		cancel()
+	}()
+
+	return out, nil
+}
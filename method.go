@@ -23,4 +23,11 @@ const (
 	Props                  Method = "props"
 	AdjustBrightness       Method = "adjust_bright"
 	AdjustColorTemperature Method = "adjust_ct"
+	SetMusic               Method = "set_music"
+	StartColorFlow         Method = "start_cf"
+	StopColorFlow          Method = "stop_cf"
+	SetScene               Method = "set_scene"
+	CronAdd                Method = "cron_add"
+	CronDel                Method = "cron_del"
+	CronGet                Method = "cron_get"
 )
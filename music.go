@@ -0,0 +1,210 @@
+package yeelight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+// MusicOption configures StartMusicMode.
+type MusicOption func(*musicOpts)
+
+type musicOpts struct {
+	host net.IP
+	port int
+}
+
+// WithMusicHost forces the local IP advertised to the bulb for the music mode
+// callback. When not set, it is guessed from the route used to reach the bulb.
+func WithMusicHost(ip net.IP) MusicOption {
+	return func(o *musicOpts) {
+		o.host = ip
+	}
+}
+
+// WithMusicPort forces the local TCP port the music mode listener binds to.
+// When not set (or set to 0), a free port is chosen automatically.
+func WithMusicPort(port int) MusicOption {
+	return func(o *musicOpts) {
+		o.port = port
+	}
+}
+
+// MusicSession is a persistent reverse connection opened by StartMusicMode.
+// Commands sent through it bypass the bulb's per-minute rate limit, but the
+// bulb does not send back any response, so its methods do not return results.
+type MusicSession struct {
+	y    *yeelight
+	conn net.Conn
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// StartMusicMode switches the yeelight into music mode.
+func (y *yeelight) StartMusicMode(ctx context.Context, opts ...MusicOption) (*MusicSession, error) {
+	cfg := &musicOpts{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	host := cfg.host
+	if host == nil {
+		ip, err := y.localIP()
+		if err != nil {
+			return nil, fmt.Errorf("[%s] could not determine local address: %w", y.address, err)
+		}
+		host = ip
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", host, cfg.port))
+	if err != nil {
+		return nil, fmt.Errorf("[%s] could not start music mode listener: %w", y.address, err)
+	}
+
+	localPort := listener.Addr().(*net.TCPAddr).Port
+	if _, err := y.send(SetMusic, 1, host.String(), localPort); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("[%s] could not enable music mode: %w", y.address, err)
+	}
+
+	type acceptResult struct {
+		conn net.Conn
+		err  error
+	}
+	acceptCh := make(chan acceptResult, 1)
+	go func() {
+		conn, err := listener.Accept()
+		acceptCh <- acceptResult{conn: conn, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		listener.Close()
+		return nil, ctx.Err()
+	case res := <-acceptCh:
+		listener.Close()
+		if res.err != nil {
+			return nil, fmt.Errorf("[%s] could not accept music mode callback: %w", y.address, res.err)
+		}
+
+		return &MusicSession{
+			y:    y,
+			conn: res.conn,
+			rnd:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		}, nil
+	}
+}
+
+// localIP guesses the local address used to route to the bulb.
+func (y *yeelight) localIP() (net.IP, error) {
+	conn, err := net.Dial("udp", y.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// write sends method with args over the music mode socket, without waiting for a response.
+func (s *MusicSession) write(method Method, args ...interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd := command{
+		ID:     s.rnd.Intn(100),
+		Method: method,
+		Params: args,
+	}
+
+	if err := json.NewEncoder(s.conn).Encode(cmd); err != nil {
+		return fmt.Errorf("could not encode command: %w", err)
+	}
+
+	if _, err := fmt.Fprint(s.conn, crlf); err != nil {
+		return fmt.Errorf("cannot write trailer: %w", err)
+	}
+
+	return nil
+}
+
+// SetRGB will set yeelight red, green and blue values.
+func (s *MusicSession) SetRGB(red, green, blue uint8) error {
+	r := uint32(red) << 16
+	g := uint32(green) << 8
+	b := uint32(blue)
+	return s.write(SetRGB, r+g+b)
+}
+
+// SetBrightness will set the yeelight brightness.
+func (s *MusicSession) SetBrightness(brightness int) error {
+	switch {
+	case brightness > 100:
+		brightness = 100
+	case brightness < 1:
+		brightness = 1
+	}
+
+	return s.write(SetBrightness, brightness)
+}
+
+// SetColorTemperature will set the yeelight color temperature.
+func (s *MusicSession) SetColorTemperature(temperature int) error {
+	switch {
+	case temperature < 1700:
+		temperature = 1700
+	case temperature > 6500:
+		temperature = 6500
+	}
+
+	return s.write(SetColorTemperatureABX, temperature)
+}
+
+// SetHSV will set the yeelight hue ([0,359]) and saturation ([0,100]).
+func (s *MusicSession) SetHSV(hue, sat int) error {
+	switch {
+	case hue < 0:
+		hue = 0
+	case hue > 359:
+		hue = 359
+	}
+
+	switch {
+	case sat < 0:
+		sat = 0
+	case sat > 100:
+		sat = 100
+	}
+
+	return s.write(SetHSV, hue, sat)
+}
+
+// StartColorFlow starts a color flow animation described by f.
+func (s *MusicSession) StartColorFlow(f Flow) error {
+	return s.write(StartColorFlow, f.Count, int(f.EndAction), f.Encode())
+}
+
+// StopColorFlow stops any color flow animation currently running.
+func (s *MusicSession) StopColorFlow() error {
+	return s.write(StopColorFlow)
+}
+
+// Close tears down the music mode socket and reverts the bulb to normal mode
+// over a fresh control connection.
+func (s *MusicSession) Close() error {
+	closeErr := s.conn.Close()
+
+	if _, err := s.y.send(SetMusic, 0); err != nil {
+		if closeErr != nil {
+			return fmt.Errorf("could not close music socket: %v, could not revert music mode: %w", closeErr, err)
+		}
+		return fmt.Errorf("could not revert music mode: %w", err)
+	}
+
+	return closeErr
+}
@@ -0,0 +1,83 @@
+package yeelight
+
+import "time"
+
+// Effect is the transition style a setter command applies on the bulb.
+type Effect string
+
+// Supported transition styles.
+const (
+	EffectSudden Effect = "sudden"
+	EffectSmooth Effect = "smooth"
+)
+
+// PowerMode selects the mode a bulb should power on into. It is only used as
+// On's optional trailing "set_power" argument.
+type PowerMode int
+
+// Supported power-on modes.
+const (
+	PowerModeNormal PowerMode = iota
+	PowerModeColorTemperature
+	PowerModeRGB
+	PowerModeHSV
+	PowerModeColorFlow
+	PowerModeNightlight
+)
+
+// minDuration is the protocol's minimum transition duration.
+const minDuration = 30 * time.Millisecond
+
+// callOpts holds the per-call settings built from a list of Option.
+type callOpts struct {
+	effect    Effect
+	duration  time.Duration
+	powerMode *PowerMode
+}
+
+// Option configures a single call to a Yeelight setter.
+type Option func(*callOpts)
+
+// WithEffect sets the transition style. Defaults to EffectSudden.
+func WithEffect(e Effect) Option {
+	return func(o *callOpts) {
+		o.effect = e
+	}
+}
+
+// WithDuration sets the transition duration. Only meaningful together with
+// WithEffect(EffectSmooth); clamped to the protocol's 30ms minimum.
+func WithDuration(d time.Duration) Option {
+	return func(o *callOpts) {
+		o.duration = d
+	}
+}
+
+// WithPowerMode selects the mode the bulb should power on into. Only
+// meaningful on On.
+func WithPowerMode(m PowerMode) Option {
+	return func(o *callOpts) {
+		o.powerMode = &m
+	}
+}
+
+// newCallOpts applies opts on top of the default sudden, 30ms transition.
+func newCallOpts(opts ...Option) callOpts {
+	c := callOpts{effect: EffectSudden, duration: minDuration}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// transitionArgs returns the trailing effect/duration params shared by
+// set_rgb, set_ct_abx, set_hsv, set_bright and set_power.
+func (c callOpts) transitionArgs() []interface{} {
+	duration := c.duration
+	if duration < minDuration {
+		duration = minDuration
+	}
+
+	return []interface{}{string(c.effect), duration.Milliseconds()}
+}
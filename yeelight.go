@@ -12,6 +12,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/SkYNewZ/go-yeelight/color"
 )
 
 var (
@@ -33,19 +35,19 @@ type Yeelight interface {
 	fmt.Stringer
 
 	// On turns on the yeelight
-	On() error
+	On(opts ...Option) error
 
 	// Off turns the yeelight
-	Off() error
+	Off(opts ...Option) error
 
 	// SetColorTemperature will set the yeelight color temperature
-	SetColorTemperature(temperature int) error
+	SetColorTemperature(temperature int, opts ...Option) error
 
 	// SetRGB will set yeelight red, green and blue values
-	SetRGB(red, green, blue uint8) error
+	SetRGB(red, green, blue uint8, opts ...Option) error
 
 	// SetBrightness will set the yeelight brightness.
-	SetBrightness(brightness int) error
+	SetBrightness(brightness int, opts ...Option) error
 
 	// IsPowerOn return whether the yeelight is power on
 	IsPowerOn() (bool, error)
@@ -65,6 +67,32 @@ type Yeelight interface {
 	// The percentage range is: (-100,100).
 	// duration is in milliseconds and minimum is 30ms.
 	AdjustColorTemperature(percentage int, duration int) error
+
+	// StartMusicMode switches the Yeelight into music mode: the bulb dials back into a
+	// TCP socket opened by the caller and then accepts commands at high frequency with
+	// no per-minute rate limit and no response. Use the returned MusicSession to send
+	// commands and call its Close method to revert the bulb to normal mode.
+	StartMusicMode(ctx context.Context, opts ...MusicOption) (*MusicSession, error)
+
+	// SetHSV will set the yeelight hue ([0,359]) and saturation ([0,100]).
+	SetHSV(hue, sat int, opts ...Option) error
+
+	// SetColor sets the yeelight to c, picking the underlying protocol method
+	// (set_rgb, set_ct_abx or set_hsv) based on c's native color.Space.
+	SetColor(c color.Color, opts ...Option) error
+
+	// SetPreset sets the yeelight to a registered color.ColorPreset.
+	SetPreset(name color.ColorPreset, opts ...Option) error
+
+	// StartColorFlow starts a color flow animation described by f.
+	StartColorFlow(f Flow) error
+
+	// StopColorFlow stops any color flow animation currently running.
+	StopColorFlow() error
+
+	// SetScene sets class's state in a single command, without the
+	// intermediate state transition other setters cause.
+	SetScene(class SceneClass, vals ...interface{}) error
 }
 
 type (
@@ -238,36 +266,50 @@ func (y *yeelight) Listen(ctx context.Context) (<-chan *Notification, error) {
 	return notificationsCh, nil
 }
 
-func (y *yeelight) On() error {
-	_, err := y.send(SetPower, "on")
+func (y *yeelight) On(opts ...Option) error {
+	cfg := newCallOpts(opts...)
+	args := append([]interface{}{"on"}, cfg.transitionArgs()...)
+	if cfg.powerMode != nil {
+		args = append(args, int(*cfg.powerMode))
+	}
+
+	_, err := y.send(SetPower, args...)
 	return err
 }
 
-func (y *yeelight) Off() error {
-	_, err := y.send(SetPower, "off")
+func (y *yeelight) Off(opts ...Option) error {
+	cfg := newCallOpts(opts...)
+	args := append([]interface{}{"off"}, cfg.transitionArgs()...)
+	_, err := y.send(SetPower, args...)
 	return err
 }
 
-func (y *yeelight) SetColorTemperature(temperature int) error {
+func (y *yeelight) SetColorTemperature(temperature int, opts ...Option) error {
 	switch {
 	case temperature < 1700:
 		temperature = 1700
 	case temperature > 6500:
 		temperature = 6500
 	}
-	_, err := y.send(SetColorTemperatureABX, temperature)
+
+	cfg := newCallOpts(opts...)
+	args := append([]interface{}{temperature}, cfg.transitionArgs()...)
+	_, err := y.send(SetColorTemperatureABX, args...)
 	return err
 }
 
-func (y *yeelight) SetRGB(red, green, blue uint8) error {
+func (y *yeelight) SetRGB(red, green, blue uint8, opts ...Option) error {
 	r := uint32(red) << 16
 	g := uint32(green) << 8
 	b := uint32(blue)
-	_, err := y.send(SetRGB, r+g+b)
+
+	cfg := newCallOpts(opts...)
+	args := append([]interface{}{r + g + b}, cfg.transitionArgs()...)
+	_, err := y.send(SetRGB, args...)
 	return err
 }
 
-func (y *yeelight) SetBrightness(brightness int) error {
+func (y *yeelight) SetBrightness(brightness int, opts ...Option) error {
 	switch {
 	case brightness > 100:
 		brightness = 100
@@ -275,7 +317,9 @@ func (y *yeelight) SetBrightness(brightness int) error {
 		brightness = 1
 	}
 
-	_, err := y.send(SetBrightness, brightness)
+	cfg := newCallOpts(opts...)
+	args := append([]interface{}{brightness}, cfg.transitionArgs()...)
+	_, err := y.send(SetBrightness, args...)
 	return err
 }
 
@@ -310,6 +354,49 @@ func (y *yeelight) AdjustBrightness(percentage int, duration int) error {
 	return err
 }
 
+func (y *yeelight) SetHSV(hue, sat int, opts ...Option) error {
+	switch {
+	case hue < 0:
+		hue = 0
+	case hue > 359:
+		hue = 359
+	}
+
+	switch {
+	case sat < 0:
+		sat = 0
+	case sat > 100:
+		sat = 100
+	}
+
+	cfg := newCallOpts(opts...)
+	args := append([]interface{}{hue, sat}, cfg.transitionArgs()...)
+	_, err := y.send(SetHSV, args...)
+	return err
+}
+
+func (y *yeelight) SetColor(c color.Color, opts ...Option) error {
+	switch c.Space() {
+	case color.SpaceHSV:
+		hsv := c.AsHSV()
+		return y.SetHSV(hsv.H, hsv.S, opts...)
+	case color.SpaceCT:
+		return y.SetColorTemperature(c.AsCT(), opts...)
+	default:
+		rgb := c.AsRGB()
+		return y.SetRGB(rgb.R, rgb.G, rgb.B, opts...)
+	}
+}
+
+func (y *yeelight) SetPreset(name color.ColorPreset, opts ...Option) error {
+	c, ok := color.Preset(name)
+	if !ok {
+		return fmt.Errorf("[%s] unknown color preset %q", y.address, name)
+	}
+
+	return y.SetColor(c, opts...)
+}
+
 func (y *yeelight) AdjustColorTemperature(percentage int, duration int) error {
 	switch {
 	case percentage > 100: